@@ -0,0 +1,639 @@
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// instanceHost is the public host this server identifies itself as in the
+// Fediverse. Override via ACTIVITYPUB_HOST (e.g. "reviews.example.com").
+var instanceHost = envOrDefault("ACTIVITYPUB_HOST", "localhost:8080")
+
+const actorUsername = "reviews"
+const activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+
+const actorPrivateKeyFile = "actor_private_key.pem"
+const followersFile = "followers.json"
+const deliveredActivitiesFile = "delivered_activities.json"
+const outboxPageSize = 20
+
+var actorPrivateKey *rsa.PrivateKey
+
+// followers holds the IRIs (actor URLs) of accounts following this actor
+var followers []string
+var followersMutex = &sync.Mutex{}
+
+// deliveredActivities dedupes Create activities already pushed to followers,
+// keyed by "<activityID>|<inbox>"
+var deliveredActivities = map[string]bool{}
+var deliveredMutex = &sync.Mutex{}
+
+func actorIRI() string {
+	return fmt.Sprintf("http://%s/actor", instanceHost)
+}
+
+// loadActorKey loads the actor's RSA keypair from disk, generating and
+// persisting a new one on first run.
+func loadActorKey() {
+	data, err := ioutil.ReadFile(actorPrivateKeyFile)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			log.Fatalf("Failed to decode actor private key PEM")
+		}
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			log.Fatalf("Failed to parse actor private key: %v", err)
+		}
+		actorPrivateKey = key
+		return
+	}
+	if !os.IsNotExist(err) {
+		log.Fatalf("Failed to read actor private key: %v", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Failed to generate actor keypair: %v", err)
+	}
+	actorPrivateKey = key
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if err := ioutil.WriteFile(actorPrivateKeyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		log.Fatalf("Failed to persist actor private key: %v", err)
+	}
+}
+
+func actorPublicKeyPEM() string {
+	der, err := x509.MarshalPKIXPublicKey(&actorPrivateKey.PublicKey)
+	if err != nil {
+		log.Fatalf("Failed to marshal actor public key: %v", err)
+	}
+	block := &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	return string(pem.EncodeToMemory(block))
+}
+
+// loadFollowers loads the followers list from disk at startup
+func loadFollowers() {
+	data, err := ioutil.ReadFile(followersFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			followers = []string{}
+			return
+		}
+		log.Fatalf("Failed to load followers: %v", err)
+	}
+	if err := json.Unmarshal(data, &followers); err != nil {
+		log.Fatalf("Failed to parse followers: %v", err)
+	}
+}
+
+func saveFollowers() {
+	data, err := json.MarshalIndent(followers, "", "  ")
+	if err != nil {
+		log.Printf("Failed to marshal followers: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(followersFile, data, 0644); err != nil {
+		log.Printf("Failed to write followers to file: %v", err)
+	}
+}
+
+// loadDeliveredActivities loads the delivery dedup set from disk at startup
+func loadDeliveredActivities() {
+	data, err := ioutil.ReadFile(deliveredActivitiesFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		log.Fatalf("Failed to load delivered activities: %v", err)
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		log.Fatalf("Failed to parse delivered activities: %v", err)
+	}
+	for _, id := range ids {
+		deliveredActivities[id] = true
+	}
+}
+
+func saveDeliveredActivities() {
+	ids := make([]string, 0, len(deliveredActivities))
+	for id := range deliveredActivities {
+		ids = append(ids, id)
+	}
+	data, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		log.Printf("Failed to marshal delivered activities: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(deliveredActivitiesFile, data, 0644); err != nil {
+		log.Printf("Failed to write delivered activities to file: %v", err)
+	}
+}
+
+// webfingerHandler resolves acct:reviews@host to the actor IRI
+func webfingerHandler(w http.ResponseWriter, r *http.Request) {
+	resource := r.URL.Query().Get("resource")
+	expected := fmt.Sprintf("acct:%s@%s", actorUsername, instanceHost)
+	if resource != expected {
+		http.Error(w, "Resource not found", http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"subject": resource,
+		"links": []map[string]string{
+			{
+				"rel":  "self",
+				"type": "application/activity+json",
+				"href": actorIRI(),
+			},
+		},
+	}
+	w.Header().Set("Content-Type", "application/jrd+json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// actorHandler returns the JSON-LD Actor document describing this server
+func actorHandler(w http.ResponseWriter, r *http.Request) {
+	iri := actorIRI()
+	response := map[string]interface{}{
+		"@context":          []string{activityStreamsContext, "https://w3id.org/security/v1"},
+		"id":                iri,
+		"type":              "Service",
+		"preferredUsername": actorUsername,
+		"name":              "Reviews",
+		"inbox":             iri + "/inbox",
+		"outbox":            iri + "/outbox",
+		"followers":         iri + "/followers",
+		"publicKey": map[string]string{
+			"id":           iri + "#main-key",
+			"owner":        iri,
+			"publicKeyPem": actorPublicKeyPEM(),
+		},
+	}
+	w.Header().Set("Content-Type", "application/activity+json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// followersHandler returns the followers OrderedCollection
+func followersHandler(w http.ResponseWriter, r *http.Request) {
+	followersMutex.Lock()
+	defer followersMutex.Unlock()
+
+	response := map[string]interface{}{
+		"@context":     activityStreamsContext,
+		"id":           actorIRI() + "/followers",
+		"type":         "OrderedCollection",
+		"totalItems":   len(followers),
+		"orderedItems": followers,
+	}
+	w.Header().Set("Content-Type", "application/activity+json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// reviewToNote converts a Review into an ActivityStreams Note
+func reviewToNote(review Review) map[string]interface{} {
+	iri := actorIRI()
+	return map[string]interface{}{
+		"id":           fmt.Sprintf("%s/reviews/%d", iri, review.ID),
+		"type":         "Note",
+		"attributedTo": iri,
+		"content":      fmt.Sprintf("%s left a review: %s", review.Name, review.Review),
+		"to":           []string{activityStreamsContext + "#Public"},
+	}
+}
+
+// reviewToCreateActivity wraps a Note in a Create activity
+func reviewToCreateActivity(review Review) map[string]interface{} {
+	iri := actorIRI()
+	return map[string]interface{}{
+		"@context": activityStreamsContext,
+		"id":       fmt.Sprintf("%s/reviews/%d/activity", iri, review.ID),
+		"type":     "Create",
+		"actor":    iri,
+		"object":   reviewToNote(review),
+		"to":       []string{activityStreamsContext + "#Public"},
+	}
+}
+
+// outboxHandler returns a paginated OrderedCollection of Create activities,
+// one per submitted review, newest first.
+func outboxHandler(w http.ResponseWriter, r *http.Request) {
+	iri := actorIRI() + "/outbox"
+
+	ordered, err := store.List(0, 0, 0)
+	if err != nil {
+		http.Error(w, "Failed to load reviews", http.StatusInternalServerError)
+		return
+	}
+	total := len(ordered)
+
+	pageParam := r.URL.Query().Get("page")
+	if pageParam == "" {
+		response := map[string]interface{}{
+			"@context":   activityStreamsContext,
+			"id":         iri,
+			"type":       "OrderedCollection",
+			"totalItems": total,
+			"first":      iri + "?page=1",
+		}
+		w.Header().Set("Content-Type", "application/activity+json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	page, err := strconv.Atoi(pageParam)
+	if err != nil || page < 1 {
+		http.Error(w, "Invalid page", http.StatusBadRequest)
+		return
+	}
+
+	start := (page - 1) * outboxPageSize
+	items := []map[string]interface{}{}
+	if start < total {
+		end := start + outboxPageSize
+		if end > total {
+			end = total
+		}
+		// Newest first: reverse-index into the stored (oldest-first) slice
+		for i := total - 1 - start; i >= total-end && i >= 0; i-- {
+			items = append(items, reviewToCreateActivity(ordered[i]))
+		}
+	}
+
+	response := map[string]interface{}{
+		"@context":     activityStreamsContext,
+		"id":           iri + "?page=" + pageParam,
+		"type":         "OrderedCollectionPage",
+		"partOf":       iri,
+		"orderedItems": items,
+	}
+	if start+outboxPageSize < total {
+		response["next"] = fmt.Sprintf("%s?page=%d", iri, page+1)
+	}
+
+	w.Header().Set("Content-Type", "application/activity+json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// inboxHandler accepts signed Follow/Undo/Delete activities from other servers
+func inboxHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	signerActorIRI, err := verifyInboundSignature(r, body)
+	if err != nil {
+		http.Error(w, "Invalid signature: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var activity map[string]interface{}
+	if err := json.Unmarshal(body, &activity); err != nil {
+		http.Error(w, "Invalid activity payload", http.StatusBadRequest)
+		return
+	}
+
+	actorIRIValue, _ := activity["actor"].(string)
+	activityType, _ := activity["type"].(string)
+
+	// The signature only proves the request was signed by whoever owns
+	// sig.keyID; without this check any actor could sign a valid request
+	// while claiming to *be* a different actor in the activity body.
+	if actorIRIValue == "" || actorIRIValue != signerActorIRI {
+		http.Error(w, "Activity actor does not match signing key's owner", http.StatusForbidden)
+		return
+	}
+
+	switch activityType {
+	case "Follow":
+		followersMutex.Lock()
+		alreadyFollowing := false
+		for _, f := range followers {
+			if f == actorIRIValue {
+				alreadyFollowing = true
+				break
+			}
+		}
+		if !alreadyFollowing {
+			followers = append(followers, actorIRIValue)
+			saveFollowers()
+		}
+		followersMutex.Unlock()
+	case "Undo":
+		object, _ := activity["object"].(map[string]interface{})
+		if object != nil && object["type"] == "Follow" {
+			followersMutex.Lock()
+			for i, f := range followers {
+				if f == actorIRIValue {
+					followers = append(followers[:i], followers[i+1:]...)
+					saveFollowers()
+					break
+				}
+			}
+			followersMutex.Unlock()
+		}
+	case "Delete":
+		// Acknowledge tombstone/account deletions from remote actors; this
+		// server keeps no cached copies of remote objects to purge.
+	default:
+		// Unrecognized activity types are accepted but otherwise ignored.
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// --- HTTP Signatures (draft-cavage-http-signatures, as used by ActivityPub) ---
+
+func digestHeader(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// buildSigningString reconstructs the exact string that was signed, per the
+// "headers" list negotiated in the Signature header. host is passed in
+// separately rather than read from headers.Get("host"): on the client side
+// net/http ignores a "Host" entry in Header (the wire value comes from
+// req.Host/req.URL.Host), and on the server side net/http strips the Host
+// header out of r.Header into r.Host before handlers ever see it - either
+// way headers.Get("host") is not a reliable source for this value.
+func buildSigningString(method, path, host string, headers http.Header, signedHeaders []string) string {
+	lines := make([]string, 0, len(signedHeaders))
+	for _, h := range signedHeaders {
+		switch h {
+		case "(request-target)":
+			lines = append(lines, fmt.Sprintf("(request-target): %s %s", strings.ToLower(method), path))
+		case "host":
+			lines = append(lines, fmt.Sprintf("host: %s", host))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s", h, headers.Get(h)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// signRequest HTTP-signs an outgoing POST per the ActivityPub convention:
+// signing over (request-target), host, date and digest with the actor's key.
+func signRequest(req *http.Request, body []byte) error {
+	now := time.Now().UTC().Format(http.TimeFormat)
+	req.Header.Set("Date", now)
+	req.Header.Set("Digest", digestHeader(body))
+
+	signedHeaders := []string{"(request-target)", "host", "date", "digest"}
+	signingString := buildSigningString(req.Method, req.URL.Path, req.URL.Host, req.Header, signedHeaders)
+
+	hashed := sha256.Sum256([]byte(signingString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, actorPrivateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return err
+	}
+
+	keyID := actorIRI() + "#main-key"
+	req.Header.Set("Signature", fmt.Sprintf(
+		`keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyID, strings.Join(signedHeaders, " "), base64.StdEncoding.EncodeToString(signature),
+	))
+	return nil
+}
+
+// parsedSignature is the decoded form of an inbound Signature header
+type parsedSignature struct {
+	keyID     string
+	headers   []string
+	signature []byte
+}
+
+func parseSignatureHeader(header string) (*parsedSignature, error) {
+	fields := map[string]string{}
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		fields[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	keyID, ok := fields["keyId"]
+	if !ok {
+		return nil, errors.New("missing keyId")
+	}
+	signatureB64, ok := fields["signature"]
+	if !ok {
+		return nil, errors.New("missing signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	headers := []string{"(request-target)", "host", "date", "digest"}
+	if h, ok := fields["headers"]; ok {
+		headers = strings.Fields(h)
+	}
+
+	return &parsedSignature{keyID: keyID, headers: headers, signature: signature}, nil
+}
+
+// fetchRemoteActorPublicKey retrieves and parses the PEM public key for a
+// remote actor referenced by a keyId IRI (e.g. "https://host/actor#main-key").
+func fetchRemoteActorPublicKey(keyID string) (*rsa.PublicKey, error) {
+	actorURL := strings.SplitN(keyID, "#", 2)[0]
+
+	req, err := http.NewRequest(http.MethodGet, actorURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/activity+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var doc struct {
+		PublicKey struct {
+			PublicKeyPem string `json:"publicKeyPem"`
+		} `json:"publicKey"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(doc.PublicKey.PublicKeyPem))
+	if block == nil {
+		return nil, errors.New("invalid publicKeyPem")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("publicKeyPem is not an RSA key")
+	}
+	return rsaPub, nil
+}
+
+// verifyInboundSignature validates an inbox POST's Signature header against
+// the sender's published publicKeyPem, and returns the IRI of the actor that
+// owns the signing key (the actor document at sig.keyID's URL, stripped of
+// its "#main-key" fragment) so the caller can check it against the activity
+// body's claimed "actor" - a valid signature only proves who signed the
+// request, not who the activity claims to be from.
+func verifyInboundSignature(r *http.Request, body []byte) (string, error) {
+	header := r.Header.Get("Signature")
+	if header == "" {
+		return "", errors.New("missing Signature header")
+	}
+	sig, err := parseSignatureHeader(header)
+	if err != nil {
+		return "", err
+	}
+
+	if r.Header.Get("Digest") != digestHeader(body) {
+		return "", errors.New("digest mismatch")
+	}
+
+	pubKey, err := fetchRemoteActorPublicKey(sig.keyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch signer key: %w", err)
+	}
+
+	signingString := buildSigningString(r.Method, r.URL.Path, r.Host, r.Header, sig.headers)
+	hashed := sha256.Sum256([]byte(signingString))
+	if err := rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, hashed[:], sig.signature); err != nil {
+		return "", errors.New("signature verification failed")
+	}
+	return strings.SplitN(sig.keyID, "#", 2)[0], nil
+}
+
+// --- Outbound delivery ---
+
+const deliveryMaxAttempts = 3
+
+// deliverReviewCreate builds a Create activity for a newly posted review and
+// pushes it to every follower's sharedInbox, retrying transient failures.
+func deliverReviewCreate(review Review) {
+	activity := reviewToCreateActivity(review)
+	activityID, _ := activity["id"].(string)
+	body, err := json.Marshal(activity)
+	if err != nil {
+		log.Printf("Failed to marshal Create activity: %v", err)
+		return
+	}
+
+	followersMutex.Lock()
+	targets := make([]string, len(followers))
+	copy(targets, followers)
+	followersMutex.Unlock()
+
+	for _, followerIRI := range targets {
+		inbox, err := fetchSharedInbox(followerIRI)
+		if err != nil {
+			log.Printf("Failed to resolve sharedInbox for %s: %v", followerIRI, err)
+			continue
+		}
+
+		dedupeKey := activityID + "|" + inbox
+		deliveredMutex.Lock()
+		alreadyDelivered := deliveredActivities[dedupeKey]
+		deliveredMutex.Unlock()
+		if alreadyDelivered {
+			continue
+		}
+
+		deliverWithRetry(inbox, body, dedupeKey)
+	}
+}
+
+func fetchSharedInbox(actorURL string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, actorURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/activity+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var doc struct {
+		Endpoints struct {
+			SharedInbox string `json:"sharedInbox"`
+		} `json:"endpoints"`
+		Inbox string `json:"inbox"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", err
+	}
+	if doc.Endpoints.SharedInbox != "" {
+		return doc.Endpoints.SharedInbox, nil
+	}
+	if doc.Inbox != "" {
+		return doc.Inbox, nil
+	}
+	return "", errors.New("actor has no inbox")
+}
+
+func deliverWithRetry(inboxURL string, body []byte, dedupeKey string) {
+	for attempt := 1; attempt <= deliveryMaxAttempts; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, inboxURL, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Failed to build delivery request to %s: %v", inboxURL, err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/activity+json")
+
+		if err := signRequest(req, body); err != nil {
+			log.Printf("Failed to sign delivery request to %s: %v", inboxURL, err)
+			return
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 || resp.StatusCode == http.StatusAccepted {
+				deliveredMutex.Lock()
+				deliveredActivities[dedupeKey] = true
+				saveDeliveredActivities()
+				deliveredMutex.Unlock()
+				return
+			}
+			log.Printf("Delivery to %s failed with status %d (attempt %d/%d)", inboxURL, resp.StatusCode, attempt, deliveryMaxAttempts)
+		} else {
+			log.Printf("Delivery to %s failed: %v (attempt %d/%d)", inboxURL, err, attempt, deliveryMaxAttempts)
+		}
+
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBuildSigningStringHostComesFromRequestHost guards against the bug where
+// the signed "host" line was reconstructed from headers.Get("host"): on the
+// server side net/http strips the Host header out of r.Header into r.Host,
+// so that lookup is always empty and every inbound signature fails to verify.
+func TestBuildSigningStringHostComesFromRequestHost(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Date", "Wed, 01 Jan 2025 00:00:00 GMT")
+	headers.Set("Digest", "SHA-256=abc")
+
+	signedHeaders := []string{"(request-target)", "host", "date", "digest"}
+	got := buildSigningString(http.MethodPost, "/inbox", "remote.example", headers, signedHeaders)
+
+	want := "(request-target): post /inbox\nhost: remote.example\ndate: Wed, 01 Jan 2025 00:00:00 GMT\ndigest: SHA-256=abc"
+	if got != want {
+		t.Fatalf("buildSigningString: got %q, want %q", got, want)
+	}
+
+	// headers.Get("host") must NOT be consulted: it's always empty for a
+	// request net/http handed to a handler.
+	emptyHostHeaders := http.Header{}
+	if s := buildSigningString(http.MethodPost, "/inbox", "", emptyHostHeaders, []string{"host"}); s != "host: " {
+		t.Fatalf("expected empty host to produce \"host: \", got %q", s)
+	}
+}
+
+// signedInboundRequest signs body the way deliverWithRetry does and returns a
+// request shaped the way the receiving server sees it: net/http has already
+// moved the Host header into r.Host and consumed the body once.
+func signedInboundRequest(t *testing.T, target string, body []byte) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	if err := signRequest(req, body); err != nil {
+		t.Fatalf("signRequest: %v", err)
+	}
+
+	inboundReq := httptest.NewRequest(req.Method, req.URL.RequestURI(), bytes.NewReader(body))
+	inboundReq.Host = req.URL.Host
+	inboundReq.Header.Set("Date", req.Header.Get("Date"))
+	inboundReq.Header.Set("Digest", req.Header.Get("Digest"))
+	inboundReq.Header.Set("Signature", req.Header.Get("Signature"))
+	return inboundReq
+}
+
+// withTestActor swaps in a fresh RSA key and points instanceHost at an
+// httptest server serving /actor, restoring both on cleanup.
+func withTestActor(t *testing.T) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+
+	origKey, origHost := actorPrivateKey, instanceHost
+	actorPrivateKey = key
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/actor", actorHandler)
+	server := httptest.NewServer(mux)
+	instanceHost = server.Listener.Addr().String()
+
+	t.Cleanup(func() {
+		server.Close()
+		actorPrivateKey, instanceHost = origKey, origHost
+	})
+}
+
+// TestSignAndVerifyInboundSignature exercises the full round trip: sign an
+// outgoing request the way deliverWithRetry does, then verify it the way
+// inboxHandler does, including fetching the signer's publicKeyPem over HTTP,
+// and checks the returned actor IRI is the signer's own.
+func TestSignAndVerifyInboundSignature(t *testing.T) {
+	withTestActor(t)
+
+	body := []byte(fmt.Sprintf(`{"type":"Follow","actor":%q}`, actorIRI()))
+	inboundReq := signedInboundRequest(t, "http://"+instanceHost+"/inbox", body)
+
+	signerActorIRI, err := verifyInboundSignature(inboundReq, body)
+	if err != nil {
+		t.Fatalf("verifyInboundSignature: %v", err)
+	}
+	if signerActorIRI != actorIRI() {
+		t.Fatalf("signerActorIRI = %q, want %q", signerActorIRI, actorIRI())
+	}
+}
+
+// TestInboxHandlerRejectsForgedActor guards against trusting the activity
+// body's "actor" field just because the request carries *some* valid
+// signature: a request signed by one actor's key but claiming to be a
+// completely different actor IRI must be rejected, not accepted as a Follow
+// from the claimed (unrelated) actor.
+func TestInboxHandlerRejectsForgedActor(t *testing.T) {
+	withTestActor(t)
+
+	origFollowers := followers
+	followers = []string{}
+	defer func() { followers = origFollowers }()
+
+	forgedActor := "http://attacker.example/actor"
+	body := []byte(fmt.Sprintf(`{"type":"Follow","actor":%q}`, forgedActor))
+	inboundReq := signedInboundRequest(t, "http://"+instanceHost+"/inbox", body)
+
+	w := httptest.NewRecorder()
+	inboxHandler(w, inboundReq)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("inboxHandler status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	for _, f := range followers {
+		if f == forgedActor {
+			t.Fatalf("forged actor %q was added to followers", forgedActor)
+		}
+	}
+}
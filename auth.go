@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// contextKey avoids collisions with context keys set by other packages
+type contextKey string
+
+const userIDContextKey contextKey = "userID"
+
+func withAuthenticatedUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// authenticatedUserIDFromContext reads the user ID attached by requireAuth
+func authenticatedUserIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(int)
+	return userID, ok
+}
+
+// User represents a registered account
+type User struct {
+	ID           int    `json:"id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash"`
+	DisplayName  string `json:"display_name"`
+	IsAdmin      bool   `json:"is_admin"`
+}
+
+// Slice to store users
+var users []User
+
+// Mutex to synchronize access to the users slice
+var usersMutex = &sync.Mutex{}
+
+// Counter to generate unique IDs for users
+var userIDCounter = 0
+
+// File to persist users
+const usersFile = "users.json"
+
+// jwtSecret signs and verifies access tokens. Override via JWT_SECRET in production.
+var jwtSecret = []byte(envOrDefault("JWT_SECRET", "dev-secret-change-me"))
+
+const tokenTTL = 24 * time.Hour
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// claims is the JWT payload carrying the authenticated user's ID
+type claims struct {
+	UserID int `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// loadUsers loads users from the file at startup
+func loadUsers() {
+	file, err := ioutil.ReadFile(usersFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			users = []User{}
+			return
+		}
+		log.Fatalf("Failed to load users: %v", err)
+	}
+
+	err = json.Unmarshal(file, &users)
+	if err != nil {
+		log.Fatalf("Failed to parse users: %v", err)
+	}
+
+	for _, u := range users {
+		if u.ID > userIDCounter {
+			userIDCounter = u.ID
+		}
+	}
+}
+
+// saveUsers saves the current users slice to a file
+func saveUsers() {
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		log.Printf("Failed to marshal users: %v", err)
+		return
+	}
+
+	err = ioutil.WriteFile(usersFile, data, 0644)
+	if err != nil {
+		log.Printf("Failed to write users to file: %v", err)
+	}
+}
+
+func findUserByEmail(email string) (*User, bool) {
+	for i := range users {
+		if users[i].Email == email {
+			return &users[i], true
+		}
+	}
+	return nil, false
+}
+
+func findUserByID(id int) (*User, bool) {
+	for i := range users {
+		if users[i].ID == id {
+			return &users[i], true
+		}
+	}
+	return nil, false
+}
+
+// registerHandler handles new account creation
+func registerHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		DisplayName string `json:"display_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if req.Email == "" || req.Password == "" {
+		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		return
+	}
+
+	usersMutex.Lock()
+	defer usersMutex.Unlock()
+
+	if _, exists := findUserByEmail(req.Email); exists {
+		http.Error(w, "Email already registered", http.StatusConflict)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, "Failed to create account", http.StatusInternalServerError)
+		return
+	}
+
+	userIDCounter++
+	newUser := User{
+		ID:           userIDCounter,
+		Email:        req.Email,
+		PasswordHash: string(hash),
+		DisplayName:  req.DisplayName,
+	}
+	if newUser.DisplayName == "" {
+		newUser.DisplayName = newUser.Email
+	}
+
+	users = append(users, newUser)
+	saveUsers()
+
+	response := map[string]interface{}{"success": true, "id": newUser.ID}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// loginHandler verifies credentials and issues a signed JWT access token
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	usersMutex.Lock()
+	user, exists := findUserByEmail(req.Email)
+	usersMutex.Unlock()
+
+	if !exists {
+		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := issueToken(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{"success": true, "token": token}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// issueToken signs a JWT access token for the given user
+func issueToken(userID int) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	})
+	return token.SignedString(jwtSecret)
+}
+
+// authenticatedUserID parses and validates the Authorization header, returning the caller's user ID
+func authenticatedUserID(r *http.Request) (int, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return 0, errors.New("missing bearer token")
+	}
+	raw := strings.TrimPrefix(header, "Bearer ")
+
+	parsed, err := jwt.ParseWithClaims(raw, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	})
+	if err != nil || !parsed.Valid {
+		return 0, errors.New("invalid token")
+	}
+
+	c, ok := parsed.Claims.(*claims)
+	if !ok {
+		return 0, errors.New("invalid token claims")
+	}
+	return c.UserID, nil
+}
+
+// requireAuth wraps a handler so it only runs for requests bearing a valid access token
+func requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := authenticatedUserID(r)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		r = r.WithContext(withAuthenticatedUserID(r.Context(), userID))
+		next(w, r)
+	}
+}
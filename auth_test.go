@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestIssueTokenAndAuthenticatedUserIDRoundTrip checks that a token issued
+// by issueToken is accepted by authenticatedUserID and yields the same
+// user ID back.
+func TestIssueTokenAndAuthenticatedUserIDRoundTrip(t *testing.T) {
+	token, err := issueToken(42)
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	userID, err := authenticatedUserID(req)
+	if err != nil {
+		t.Fatalf("authenticatedUserID: %v", err)
+	}
+	if userID != 42 {
+		t.Fatalf("userID = %d, want 42", userID)
+	}
+}
+
+func TestAuthenticatedUserIDRejectsMissingOrGarbledToken(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+	}{
+		{"missing header", ""},
+		{"no bearer prefix", "sometoken"},
+		{"garbled bearer token", "Bearer not-a-jwt"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			if _, err := authenticatedUserID(req); err == nil {
+				t.Fatal("authenticatedUserID: expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestAuthenticatedUserIDRejectsExpiredToken(t *testing.T) {
+	now := time.Now()
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: 7,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now.Add(-2 * tokenTTL)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(-tokenTTL)),
+		},
+	})
+	signed, err := expired.SignedString(jwtSecret)
+	if err != nil {
+		t.Fatalf("signing expired token: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+
+	if _, err := authenticatedUserID(req); err == nil {
+		t.Fatal("authenticatedUserID: expected error for expired token, got nil")
+	}
+}
+
+func TestRequireAuthRejectsUnauthenticatedRequests(t *testing.T) {
+	called := false
+	handler := requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+	w := httptest.NewRecorder()
+	handler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Fatal("wrapped handler should not run without a valid token")
+	}
+}
+
+func TestRequireAuthPassesUserIDToWrappedHandler(t *testing.T) {
+	token, err := issueToken(99)
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+
+	var gotUserID int
+	var gotOK bool
+	handler := requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, gotOK = authenticatedUserIDFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if !gotOK || gotUserID != 99 {
+		t.Fatalf("authenticatedUserIDFromContext = (%d, %v), want (99, true)", gotUserID, gotOK)
+	}
+}
+
+// withTestStoreAndUsers swaps in a fresh JSONStore (seeded with initialReview)
+// and users slice for the duration of the test, restoring both on cleanup.
+func withTestStoreAndUsers(t *testing.T, initialReview Review, initialUsers []User) {
+	t.Helper()
+	testStore, err := NewJSONStore(filepath.Join(t.TempDir(), "reviews.json"))
+	if err != nil {
+		t.Fatalf("NewJSONStore: %v", err)
+	}
+	if _, err := testStore.Add(initialReview); err != nil {
+		t.Fatalf("seeding store: %v", err)
+	}
+
+	origStore, origUsers := store, users
+	store = testStore
+	users = initialUsers
+
+	t.Cleanup(func() {
+		testStore.Close()
+		store, users = origStore, origUsers
+	})
+}
+
+func TestDeleteReviewHandlerOwnershipAndAdmin(t *testing.T) {
+	cases := []struct {
+		name       string
+		callerID   int
+		wantStatus int
+	}{
+		{"owner may delete", 1, http.StatusOK},
+		{"admin may delete another author's review", 2, http.StatusOK},
+		{"non-owner non-admin is forbidden", 3, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withTestStoreAndUsers(t,
+				Review{ID: 1, Name: "n", Review: "r", AuthorID: 1},
+				[]User{
+					{ID: 1, Email: "owner@example.com"},
+					{ID: 2, Email: "admin@example.com", IsAdmin: true},
+					{ID: 3, Email: "rando@example.com"},
+				},
+			)
+
+			body := strings.NewReader(fmt.Sprintf(`{"id":%d}`, 1))
+			req := httptest.NewRequest(http.MethodDelete, "/delete-review", body)
+			req = req.WithContext(withAuthenticatedUserID(req.Context(), tc.callerID))
+			w := httptest.NewRecorder()
+
+			deleteReviewHandler(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+
+			_, found, err := store.Get(1)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if tc.wantStatus == http.StatusOK && found {
+				t.Fatal("review should have been deleted")
+			}
+			if tc.wantStatus != http.StatusOK && !found {
+				t.Fatal("review should not have been deleted")
+			}
+		})
+	}
+}
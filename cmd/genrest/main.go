@@ -0,0 +1,42 @@
+// Command genrest scans a package for +genrest:resource-annotated structs
+// and writes a generated Go file with decode/validate wrappers and an
+// OpenAPI document, analogous to how GoVPP generates per-RPC boilerplate
+// from .api definitions.
+//
+// Usage:
+//
+//	go run ./cmd/genrest -pkg . -out rest_gen.go
+//
+// It's invoked via `go generate` from main.go.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/namithsaliyan/ReviewGO/gen/restgen"
+)
+
+func main() {
+	pkgDir := flag.String("pkg", ".", "directory of the package to scan for +genrest:resource structs")
+	outPath := flag.String("out", "rest_gen.go", "path to write the generated Go source to")
+	flag.Parse()
+
+	resources, err := restgen.ScanPackage(*pkgDir)
+	if err != nil {
+		log.Fatalf("genrest: %v", err)
+	}
+	if len(resources) == 0 {
+		log.Fatalf("genrest: no +genrest:resource structs found in %s", *pkgDir)
+	}
+
+	src, err := restgen.EmitGo("main", resources)
+	if err != nil {
+		log.Fatalf("genrest: %v", err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0644); err != nil {
+		log.Fatalf("genrest: writing %s: %v", *outPath, err)
+	}
+}
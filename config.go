@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// storageConfigFile is an optional JSON config consulted before flags, e.g.:
+//
+//	{"storage": "sqlite", "dsn": "reviews.db"}
+const storageConfigFile = "storage_config.json"
+
+// StorageConfig selects which Store implementation backs the server.
+type StorageConfig struct {
+	Storage string `json:"storage"` // "json", "sqlite", or "postgres"
+	DSN     string `json:"dsn"`     // file path (json/sqlite) or connection string (postgres)
+}
+
+// loadStorageConfig reads storage_config.json if present, then lets
+// -storage/-dsn flags override it.
+func loadStorageConfig() StorageConfig {
+	cfg := StorageConfig{Storage: "json", DSN: reviewsFile}
+
+	if data, err := ioutil.ReadFile(storageConfigFile); err == nil {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			fmt.Printf("Warning: ignoring invalid %s: %v\n", storageConfigFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		fmt.Printf("Warning: could not read %s: %v\n", storageConfigFile, err)
+	}
+
+	storage := flag.String("storage", cfg.Storage, "review storage backend: json, sqlite, or postgres")
+	dsn := flag.String("dsn", cfg.DSN, "storage DSN (file path for json/sqlite, connection string for postgres)")
+	flag.Parse()
+
+	cfg.Storage = *storage
+	cfg.DSN = *dsn
+	return cfg
+}
+
+// newStore builds the Store selected by cfg.
+func newStore(cfg StorageConfig) (Store, error) {
+	switch cfg.Storage {
+	case "json":
+		return NewJSONStore(cfg.DSN)
+	case "sqlite":
+		return NewSQLiteStore(cfg.DSN)
+	case "postgres":
+		return NewPostgresStore(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage)
+	}
+}
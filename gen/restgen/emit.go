@@ -0,0 +1,195 @@
+package restgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// EmitGo renders the generated Go source (decode/validate wrappers + an
+// OpenAPI document) for the given resources into a single file.
+func EmitGo(pkgName string, resources []Resource) ([]byte, error) {
+	tmpl := template.Must(template.New("gen").Funcs(template.FuncMap{
+		"openapiType": openapiType,
+		"lower":       strings.ToLower,
+		"deref":       func(p *int) int { return *p },
+	}).Parse(goTemplate))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		PkgName     string
+		Resources   []Resource
+		NeedsRegexp bool
+	}{PkgName: pkgName, Resources: resources, NeedsRegexp: anyFieldHasRegexp(resources)}); err != nil {
+		return nil, fmt.Errorf("restgen: rendering template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("restgen: formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+func anyFieldHasRegexp(resources []Resource) bool {
+	for _, r := range resources {
+		for _, f := range r.Fields {
+			if f.Regexp != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func openapiType(goType string) string {
+	switch goType {
+	case "int":
+		return "integer"
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "time.Time":
+		return "string"
+	default:
+		return "string"
+	}
+}
+
+const goTemplate = `// Code generated by cmd/genrest from +genrest:resource annotations. DO NOT EDIT.
+
+package {{.PkgName}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+{{- if .NeedsRegexp}}
+	"regexp"
+{{- end}}
+)
+
+{{range .Resources}}
+{{$res := .}}
+{{- range .Fields}}
+{{- if .Regexp}}
+var {{$res.StructName}}{{.Name}}Pattern = regexp.MustCompile(` + "`" + `{{.Regexp}}` + "`" + `)
+{{- end}}
+{{- end}}
+
+// Decode{{.StructName}} decodes and validates a {{.StructName}} from an HTTP
+// request body, applying the validate tags declared on {{.StructName}}.
+func Decode{{.StructName}}(r *http.Request) ({{.StructName}}, error) {
+	var v {{.StructName}}
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		return v, fmt.Errorf("invalid request payload: %w", err)
+	}
+	if err := Validate{{.StructName}}(v); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Validate{{.StructName}} applies the validate tags declared on {{.StructName}}.
+func Validate{{.StructName}}(v {{.StructName}}) error {
+{{- range .Fields}}
+{{- if .Required}}
+	if v.{{.Name}} == "" {
+		return fmt.Errorf("{{.JSONName}} is required")
+	}
+{{- end}}
+{{- if .Min}}
+	if v.{{.Name}} < {{deref .Min}} {
+		return fmt.Errorf("{{.JSONName}} must be at least {{deref .Min}}")
+	}
+{{- end}}
+{{- if .Max}}
+	if v.{{.Name}} > {{deref .Max}} {
+		return fmt.Errorf("{{.JSONName}} must be at most {{deref .Max}}")
+	}
+{{- end}}
+{{- if .Regexp}}
+	if !{{$res.StructName}}{{.Name}}Pattern.MatchString(v.{{.Name}}) {
+		return fmt.Errorf("{{.JSONName}} is not in a valid format")
+	}
+{{- end}}
+{{- end}}
+	return nil
+}
+{{end}}
+
+// openAPISpec is served verbatim at GET /openapi.json.
+var openAPISpec = map[string]interface{}{
+	"openapi": "3.0.3",
+	"info": map[string]interface{}{
+		"title":   "ReviewGO API",
+		"version": "1.0.0",
+	},
+	"paths": map[string]interface{}{
+{{- range .Resources}}
+{{$res := .}}
+		"{{.Path}}": map[string]interface{}{
+{{- range .Verbs}}
+			"{{. | lower}}": map[string]interface{}{
+				"summary": "{{$.PkgName}} {{.}}",
+{{- if eq (. | lower) "post"}}
+				"requestBody": map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+{{- range $res.Fields}}
+{{- if not .ReadOnly}}
+									"{{.JSONName}}": map[string]interface{}{"type": "{{openapiType .GoType}}"},
+{{- end}}
+{{- end}}
+								},
+							},
+						},
+					},
+				},
+{{- end}}
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "OK"},
+				},
+			},
+{{- end}}
+		},
+{{- end}}
+	},
+}
+
+// openapiHandler serves the generated OpenAPI document.
+func openapiHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(openAPISpec)
+}
+
+// docsHandler serves a Swagger UI page pointed at /openapi.json.
+func docsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, swaggerUIPage)
+}
+
+const swaggerUIPage = ` + "`" + `<!DOCTYPE html>
+<html>
+<head>
+	<title>ReviewGO API Docs</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+	<script>
+		window.onload = function() {
+			SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+		};
+	</script>
+</body>
+</html>
+` + "`" + `
+`
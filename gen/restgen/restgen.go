@@ -0,0 +1,193 @@
+// Package restgen generates typed decode/validate wrappers and an OpenAPI
+// 3.0 document from annotated Go structs, the same way GoVPP generates a
+// RESTHandler per RPC service from its .api definitions: the source of
+// truth is the struct declaration, and the repetitive request-handling code
+// is mechanical output from it. It does not generate mux route
+// registration - handlers still wire Decode<Struct>/Validate<Struct> into
+// http.HandleFunc by hand, since routes here carry request-specific
+// concerns (auth, ownership checks, federation) that aren't expressible as
+// struct tags.
+//
+// A struct opts in with a "+genrest:resource" doc comment directive on the
+// type, and drives per-field validation with `validate` struct tags:
+//
+//	// +genrest:resource=reviews path=/reviews verbs=GET,POST
+//	type Review struct {
+//		Name   string `json:"name" validate:"required"`
+//		Rating int    `json:"rating" validate:"min=1,max=5"`
+//	}
+package restgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Field describes one struct field relevant to REST decode/validation.
+type Field struct {
+	Name     string // Go field name, e.g. "Rating"
+	JSONName string // json tag name, e.g. "rating"
+	GoType   string // e.g. "int", "string", "time.Time"
+	Required bool
+	Min      *int
+	Max      *int
+	Regexp   string
+	ReadOnly bool // present in responses but rejected/ignored on write
+}
+
+// Resource is one +genrest:resource-annotated struct.
+type Resource struct {
+	StructName string
+	Name       string // resource path segment, e.g. "reviews"
+	Path       string // e.g. "/reviews"
+	Verbs      []string
+	Fields     []Field
+}
+
+// ScanPackage parses every .go file in dir and returns the resources
+// declared via "+genrest:resource" doc comments.
+func ScanPackage(dir string) ([]Resource, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("restgen: parsing %s: %w", dir, err)
+	}
+
+	var resources []Resource
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE || genDecl.Doc == nil {
+					continue
+				}
+				directive := findResourceDirective(genDecl.Doc)
+				if directive == "" {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					resource, err := parseResource(typeSpec.Name.Name, directive, structType)
+					if err != nil {
+						return nil, err
+					}
+					resources = append(resources, resource)
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// findResourceDirective returns the raw "+genrest:resource=..." line from a
+// doc comment group, or "" if none is present.
+func findResourceDirective(doc *ast.CommentGroup) string {
+	for _, c := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if strings.HasPrefix(text, "+genrest:resource") {
+			return text
+		}
+	}
+	return ""
+}
+
+func parseResource(structName, directive string, structType *ast.StructType) (Resource, error) {
+	resource := Resource{StructName: structName, Verbs: []string{"GET"}}
+
+	for _, field := range strings.Fields(directive) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimPrefix(kv[0], "+genrest:") {
+		case "resource":
+			resource.Name = kv[1]
+		case "path":
+			resource.Path = kv[1]
+		case "verbs":
+			resource.Verbs = strings.Split(kv[1], ",")
+		}
+	}
+	if resource.Name == "" {
+		return Resource{}, fmt.Errorf("restgen: %s: missing resource= in directive", structName)
+	}
+	if resource.Path == "" {
+		resource.Path = "/" + resource.Name
+	}
+
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		ident, ok := field.Type.(*ast.Ident)
+		goType := "interface{}"
+		if ok {
+			goType = ident.Name
+		} else if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				goType = pkg.Name + "." + sel.Sel.Name
+			}
+		}
+
+		f := Field{Name: field.Names[0].Name, GoType: goType, JSONName: strings.ToLower(field.Names[0].Name)}
+		if field.Tag != nil {
+			tag := strings.Trim(field.Tag.Value, "`")
+			if jsonName := structTagValue(tag, "json"); jsonName != "" {
+				f.JSONName = strings.Split(jsonName, ",")[0]
+			}
+			if validate := structTagValue(tag, "validate"); validate != "" {
+				applyValidateTag(&f, validate)
+			}
+			if rest := structTagValue(tag, "rest"); rest == "readonly" {
+				f.ReadOnly = true
+			}
+		}
+		resource.Fields = append(resource.Fields, f)
+	}
+
+	return resource, nil
+}
+
+func applyValidateTag(f *Field, validate string) {
+	for _, rule := range strings.Split(validate, ",") {
+		switch {
+		case rule == "required":
+			f.Required = true
+		case strings.HasPrefix(rule, "min="):
+			if v, err := strconv.Atoi(strings.TrimPrefix(rule, "min=")); err == nil {
+				f.Min = &v
+			}
+		case strings.HasPrefix(rule, "max="):
+			if v, err := strconv.Atoi(strings.TrimPrefix(rule, "max=")); err == nil {
+				f.Max = &v
+			}
+		case strings.HasPrefix(rule, "regexp="):
+			f.Regexp = strings.TrimPrefix(rule, "regexp=")
+		}
+	}
+}
+
+// structTagValue extracts the value of key from a raw (unquoted) struct tag
+// string without pulling in reflect.StructTag, since the tag here is source
+// text, not a live Go value.
+func structTagValue(tag, key string) string {
+	for _, part := range strings.Fields(tag) {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 || kv[0] != key {
+			continue
+		}
+		return strings.Trim(kv[1], `"`)
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package restgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testFixture = `package fixture
+
+// +genrest:resource=widgets path=/widgets verbs=GET,POST
+type Widget struct {
+	ID    int    ` + "`" + `json:"id" rest:"readonly"` + "`" + `
+	Name  string ` + "`" + `json:"name" validate:"required"` + "`" + `
+	Count int    ` + "`" + `json:"count" validate:"min=1,max=10"` + "`" + `
+	Code  string ` + "`" + `json:"code" validate:"regexp=^[A-Z]{3}$"` + "`" + `
+}
+`
+
+func writeFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fixture.go"), []byte(testFixture), 0644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	return dir
+}
+
+func TestScanPackageParsesDirectiveAndValidateTags(t *testing.T) {
+	resources, err := ScanPackage(writeFixture(t))
+	if err != nil {
+		t.Fatalf("ScanPackage: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+
+	res := resources[0]
+	if res.Name != "widgets" || res.Path != "/widgets" {
+		t.Fatalf("resource = %+v, want Name=widgets Path=/widgets", res)
+	}
+	if got, want := res.Verbs, []string{"GET", "POST"}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Verbs = %v, want %v", got, want)
+	}
+
+	byName := map[string]Field{}
+	for _, f := range res.Fields {
+		byName[f.Name] = f
+	}
+
+	if !byName["ID"].ReadOnly {
+		t.Error("ID should be ReadOnly")
+	}
+	if !byName["Name"].Required {
+		t.Error("Name should be Required")
+	}
+	if byName["Count"].Min == nil || *byName["Count"].Min != 1 {
+		t.Errorf("Count.Min = %v, want 1", byName["Count"].Min)
+	}
+	if byName["Count"].Max == nil || *byName["Count"].Max != 10 {
+		t.Errorf("Count.Max = %v, want 10", byName["Count"].Max)
+	}
+	if byName["Code"].Regexp != "^[A-Z]{3}$" {
+		t.Errorf("Code.Regexp = %q, want ^[A-Z]{3}$", byName["Code"].Regexp)
+	}
+}
+
+func TestEmitGoProducesValidGoAndEnforcesDeclaredRules(t *testing.T) {
+	resources, err := ScanPackage(writeFixture(t))
+	if err != nil {
+		t.Fatalf("ScanPackage: %v", err)
+	}
+
+	src, err := EmitGo("fixture", resources)
+	if err != nil {
+		t.Fatalf("EmitGo: %v", err)
+	}
+
+	got := string(src)
+	for _, want := range []string{
+		`if v.Name == "" {`,
+		`if v.Count < 1 {`,
+		`if v.Count > 10 {`,
+		`WidgetCodePattern.MatchString(v.Code)`,
+		`"code":  map[string]interface{}{"type": "string"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source missing %q\n---\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// JSONStore is the original flat-file Store: the whole review list is kept
+// in memory and rewritten to disk on every mutation. Simple and fine for a
+// handful of reviews; SQLiteStore or PostgresStore scale better.
+type JSONStore struct {
+	path      string
+	mutex     sync.Mutex
+	reviews   []Review
+	idCounter int
+}
+
+// NewJSONStore loads (or creates) a JSON-backed Store at path.
+func NewJSONStore(path string) (*JSONStore, error) {
+	s := &JSONStore{path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.reviews = []Review{}
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &s.reviews); err != nil {
+		return nil, err
+	}
+	for _, review := range s.reviews {
+		if review.ID > s.idCounter {
+			s.idCounter = review.ID
+		}
+	}
+	return s, nil
+}
+
+func (s *JSONStore) save() {
+	data, err := json.MarshalIndent(s.reviews, "", "  ")
+	if err != nil {
+		log.Printf("Failed to marshal reviews: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(s.path, data, 0644); err != nil {
+		log.Printf("Failed to write reviews to file: %v", err)
+	}
+}
+
+func (s *JSONStore) Add(review Review) (Review, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.idCounter++
+	review.ID = s.idCounter
+	if review.CreatedAt.IsZero() {
+		review.CreatedAt = time.Now()
+	}
+	s.reviews = append(s.reviews, review)
+	s.save()
+	return review, nil
+}
+
+func (s *JSONStore) Get(id int) (Review, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, review := range s.reviews {
+		if review.ID == id {
+			return review, true, nil
+		}
+	}
+	return Review{}, false, nil
+}
+
+func (s *JSONStore) List(offset, limit, authorID int) ([]Review, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	matched := make([]Review, 0, len(s.reviews))
+	for _, review := range s.reviews {
+		if authorID != 0 && review.AuthorID != authorID {
+			continue
+		}
+		matched = append(matched, review)
+	}
+
+	if offset >= len(matched) {
+		return []Review{}, nil
+	}
+	end := len(matched)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return matched[offset:end], nil
+}
+
+func (s *JSONStore) Delete(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, review := range s.reviews {
+		if review.ID == id {
+			s.reviews = append(s.reviews[:i], s.reviews[i+1:]...)
+			s.save()
+			return nil
+		}
+	}
+	return ErrReviewNotFound
+}
+
+func (s *JSONStore) Update(review Review) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, existing := range s.reviews {
+		if existing.ID == review.ID {
+			s.reviews[i] = review
+			s.save()
+			return nil
+		}
+	}
+	return ErrReviewNotFound
+}
+
+func (s *JSONStore) Close() error {
+	return nil
+}
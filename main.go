@@ -3,88 +3,86 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
-	"sync"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+//go:generate go run ./cmd/genrest -pkg . -out rest_gen.go
+
 // Review represents a review submitted by a user
+//
+// +genrest:resource=reviews path=/reviews verbs=GET,POST
 type Review struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Review string `json:"review"`
+	ID        int       `json:"id" rest:"readonly"`
+	Name      string    `json:"name" validate:"required"`
+	Review    string    `json:"review" validate:"required"`
+	AuthorID  int       `json:"author_id" rest:"readonly"`
+	Rating    int       `json:"rating" validate:"min=1,max=5"`
+	CreatedAt time.Time `json:"created_at" rest:"readonly"`
 }
 
-// Slice to store reviews
-var reviews []Review
-
-// Mutex to synchronize access to the reviews slice
-var mutex = &sync.Mutex{}
-
-// Counter to generate unique IDs for reviews
-var idCounter = 0
-
-// File to persist reviews
-const reviewsFile = "reviews.json"
-
-func main() {
-	// Load existing reviews from the file
-	loadReviews()
-
-	http.HandleFunc("/reviews", reviewsHandler)
-	http.HandleFunc("/delete-review", deleteReviewHandler) // New handler for deleting a review
-
-	fmt.Println("Server is listening on port 8080...")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+// PublicReview is the shape returned by GET /reviews: a Review plus the
+// author's display name, without leaking AuthorID to anonymous clients.
+type PublicReview struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Review     string `json:"review"`
+	AuthorName string `json:"author_name"`
 }
 
-// loadReviews loads reviews from the file at startup
-func loadReviews() {
-	file, err := ioutil.ReadFile(reviewsFile)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// File doesn't exist, no reviews to load
-			reviews = []Review{}
-			return
-		}
-		log.Fatalf("Failed to load reviews: %v", err)
-	}
+// File to persist reviews when using the JSON storage backend
+const reviewsFile = "reviews.json"
 
-	// Parse JSON data into the reviews slice
-	err = json.Unmarshal(file, &reviews)
-	if err != nil {
-		log.Fatalf("Failed to parse reviews: %v", err)
-	}
+// store is the active Store implementation, selected at startup by -storage/-dsn
+var store Store
 
-	// Set the idCounter to the highest ID found
-	for _, review := range reviews {
-		if review.ID > idCounter {
-			idCounter = review.ID
-		}
-	}
-}
-
-// saveReviews saves the current reviews slice to a file
-func saveReviews() {
-	data, err := json.MarshalIndent(reviews, "", "  ")
-	if err != nil {
-		log.Printf("Failed to marshal reviews: %v", err)
-		return
-	}
+func main() {
+	cfg := loadStorageConfig()
 
-	err = ioutil.WriteFile(reviewsFile, data, 0644)
+	var err error
+	store, err = newStore(cfg)
 	if err != nil {
-		log.Printf("Failed to write reviews to file: %v", err)
+		log.Fatalf("Failed to initialize %s storage: %v", cfg.Storage, err)
 	}
+	defer store.Close()
+	registerReviewsGauge()
+
+	loadUsers()
+	loadActorKey()
+	loadFollowers()
+	loadDeliveredActivities()
+
+	http.HandleFunc("/register", registerHandler)
+	http.HandleFunc("/login", loginHandler)
+	http.HandleFunc("/reviews", instrumentLatency("/reviews", reviewsHandler))
+	http.HandleFunc("/reviews/stats", instrumentLatency("/reviews/stats", statsHandler))
+	http.HandleFunc("/delete-review", instrumentLatency("/delete-review", requireAuth(deleteReviewHandler))) // Only the owner (or an admin) may delete
+	http.Handle("/metrics", promhttp.Handler())
+
+	// Generated from +genrest:resource annotations (see cmd/genrest)
+	http.HandleFunc("/openapi.json", openapiHandler)
+	http.HandleFunc("/docs", docsHandler)
+
+	// ActivityPub: expose this server as a Fediverse actor
+	http.HandleFunc("/.well-known/webfinger", webfingerHandler)
+	http.HandleFunc("/actor", actorHandler)
+	http.HandleFunc("/actor/inbox", inboxHandler)
+	http.HandleFunc("/actor/outbox", outboxHandler)
+	http.HandleFunc("/actor/followers", followersHandler)
+
+	fmt.Printf("Server is listening on port 8080 (storage: %s)...\n", cfg.Storage)
+	log.Fatal(http.ListenAndServe(":8080", nil))
 }
 
 // reviewsHandler handles both POST and GET requests for reviews
 func reviewsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		handlePostReview(w, r)
+		requireAuth(handlePostReview)(w, r)
 	case http.MethodGet:
 		handleGetReviews(w, r)
 	default:
@@ -92,27 +90,32 @@ func reviewsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handlePostReview handles the submission of a new review
+// handlePostReview handles the submission of a new review by an authenticated user
 func handlePostReview(w http.ResponseWriter, r *http.Request) {
-	// Parse the JSON request body
-	var newReview Review
-	if err := json.NewDecoder(r.Body).Decode(&newReview); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+	authorID, ok := authenticatedUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	// Lock the mutex before modifying the slice
-	mutex.Lock()
-	defer mutex.Unlock()
-
-	// Assign a unique ID to the new review
-	idCounter++
-	newReview.ID = idCounter
+	// Decode and validate the request body per Review's validate tags
+	newReview, err := DecodeReview(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	newReview.AuthorID = authorID
+	newReview.CreatedAt = time.Now()
 
-	reviews = append(reviews, newReview)
+	newReview, err = store.Add(newReview)
+	if err != nil {
+		http.Error(w, "Failed to save review", http.StatusInternalServerError)
+		return
+	}
+	reviewsCreatedTotal.Inc()
 
-	// Save reviews to the file
-	saveReviews()
+	// Federate the new review to followers as a signed Create activity
+	go deliverReviewCreate(newReview)
 
 	// Respond with success and the assigned ID
 	response := map[string]interface{}{"success": true, "id": newReview.ID}
@@ -120,24 +123,97 @@ func handlePostReview(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleGetReviews handles fetching all submitted reviews
+// queryInt parses a query param as an int, defaulting to 0 if absent or invalid.
+func queryInt(r *http.Request, name string) int {
+	v, _ := strconv.Atoi(r.URL.Query().Get(name))
+	return v
+}
+
+// handleGetReviews handles fetching submitted reviews, enriched with the
+// author's display name. Supports ?offset=&limit=&author_id= for pagination
+// and filtering by author; all default to 0 (no offset/limit, all authors).
 func handleGetReviews(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// Lock the mutex before reading the slice
-	mutex.Lock()
-	defer mutex.Unlock()
+	offset := queryInt(r, "offset")
+	limit := queryInt(r, "limit")
+	authorID := queryInt(r, "author_id")
+
+	reviewList, err := store.List(offset, limit, authorID)
+	if err != nil {
+		http.Error(w, "Failed to load reviews", http.StatusInternalServerError)
+		return
+	}
+
+	usersMutex.Lock()
+	defer usersMutex.Unlock()
+
+	public := make([]PublicReview, 0, len(reviewList))
+	for _, review := range reviewList {
+		authorName := "unknown"
+		if author, ok := findUserByID(review.AuthorID); ok {
+			authorName = author.DisplayName
+		}
+		public = append(public, PublicReview{
+			ID:         review.ID,
+			Name:       review.Name,
+			Review:     review.Review,
+			AuthorName: authorName,
+		})
+	}
 
-	json.NewEncoder(w).Encode(reviews)
+	json.NewEncoder(w).Encode(public)
 }
 
-// deleteReviewHandler handles the deletion of a review by ID
+// statsHandler returns the average rating, review count, and a histogram of
+// reviews per star value (1-5).
+func statsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reviewList, err := store.List(0, 0, 0)
+	if err != nil {
+		http.Error(w, "Failed to load reviews", http.StatusInternalServerError)
+		return
+	}
+
+	histogram := map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}
+	sum := 0
+	for _, review := range reviewList {
+		histogram[review.Rating]++
+		sum += review.Rating
+	}
+
+	average := 0.0
+	if len(reviewList) > 0 {
+		average = float64(sum) / float64(len(reviewList))
+	}
+
+	response := map[string]interface{}{
+		"count":          len(reviewList),
+		"average_rating": average,
+		"histogram":      histogram,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteReviewHandler handles the deletion of a review by ID. Only the review's
+// author or an admin user may delete it.
 func deleteReviewHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	callerID, ok := authenticatedUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	// Parse the JSON request body to get the ID of the review to delete
 	var requestData struct {
 		ID int `json:"id"`
@@ -147,29 +223,32 @@ func deleteReviewHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Lock the mutex before modifying the slice
-	mutex.Lock()
-	defer mutex.Unlock()
-
-	// Find and remove the review with the specified ID
-	index := -1
-	for i, review := range reviews {
-		if review.ID == requestData.ID {
-			index = i
-			break
-		}
+	review, found, err := store.Get(requestData.ID)
+	if err != nil {
+		http.Error(w, "Failed to look up review", http.StatusInternalServerError)
+		return
 	}
-
-	if index == -1 {
+	if !found {
 		http.Error(w, "Review not found", http.StatusNotFound)
 		return
 	}
 
-	// Remove the review from the slice
-	reviews = append(reviews[:index], reviews[index+1:]...)
+	usersMutex.Lock()
+	caller, callerExists := findUserByID(callerID)
+	usersMutex.Unlock()
 
-	// Save reviews to the file
-	saveReviews()
+	isOwner := review.AuthorID == callerID
+	isAdmin := callerExists && caller.IsAdmin
+	if !isOwner && !isAdmin {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	if err := store.Delete(requestData.ID); err != nil {
+		http.Error(w, "Failed to delete review", http.StatusInternalServerError)
+		return
+	}
+	reviewsDeletedTotal.Inc()
 
 	// Respond with success
 	response := map[string]bool{"success": true}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// reviewsCreatedTotal counts successful POST /reviews calls
+	reviewsCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reviews_created_total",
+		Help: "Total number of reviews created.",
+	})
+
+	// reviewsDeletedTotal counts successful DELETE /delete-review calls
+	reviewsDeletedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reviews_deleted_total",
+		Help: "Total number of reviews deleted.",
+	})
+
+	// httpRequestDuration tracks handler latency by route and method
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP handler latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"path", "method"})
+)
+
+// registerReviewsGauge exposes the current review count as a Prometheus gauge.
+// Must be called after store is initialized.
+func registerReviewsGauge() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "reviews_total",
+		Help: "Current number of stored reviews.",
+	}, func() float64 {
+		reviewList, err := store.List(0, 0, 0)
+		if err != nil {
+			return 0
+		}
+		return float64(len(reviewList))
+	})
+}
+
+// instrumentLatency wraps a handler to record its duration under the given route label
+func instrumentLatency(path string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next(w, r)
+		httpRequestDuration.WithLabelValues(path, r.Method).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+// TestValidateReviewRejectsEmptyRequiredFields guards the required/regexp
+// struct tag rules declared on Review against the generator silently
+// dropping them (min/max were already enforced; required was not).
+func TestValidateReviewRejectsEmptyRequiredFields(t *testing.T) {
+	cases := []struct {
+		name    string
+		review  Review
+		wantErr bool
+	}{
+		{"valid", Review{Name: "Alice", Review: "Great stuff", Rating: 5}, false},
+		{"empty name", Review{Name: "", Review: "Great stuff", Rating: 5}, true},
+		{"empty review", Review{Name: "Alice", Review: "", Rating: 5}, true},
+		{"rating too low", Review{Name: "Alice", Review: "Great stuff", Rating: 0}, true},
+		{"rating too high", Review{Name: "Alice", Review: "Great stuff", Rating: 6}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateReview(tc.review)
+			if tc.wantErr && err == nil {
+				t.Fatalf("ValidateReview(%+v) = nil, want error", tc.review)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("ValidateReview(%+v) = %v, want nil", tc.review, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"database/sql"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a Store backed by a SQLite database, indexed by review ID
+// and safe for concurrent use without an application-level mutex.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (and migrates) a SQLite database at dsn, e.g. "reviews.db".
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS reviews (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	name       TEXT NOT NULL,
+	review     TEXT NOT NULL,
+	author_id  INTEGER NOT NULL DEFAULT 0,
+	rating     INTEGER NOT NULL DEFAULT 0,
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_reviews_author_id ON reviews(author_id);
+`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Add(review Review) (Review, error) {
+	if review.CreatedAt.IsZero() {
+		review.CreatedAt = time.Now()
+	}
+	result, err := s.db.Exec(
+		`INSERT INTO reviews (name, review, author_id, rating, created_at) VALUES (?, ?, ?, ?, ?)`,
+		review.Name, review.Review, review.AuthorID, review.Rating, review.CreatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return Review{}, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Review{}, err
+	}
+	review.ID = int(id)
+	return review, nil
+}
+
+func (s *SQLiteStore) Get(id int) (Review, bool, error) {
+	var review Review
+	var createdAt string
+	err := s.db.QueryRow(
+		`SELECT id, name, review, author_id, rating, created_at FROM reviews WHERE id = ?`, id,
+	).Scan(&review.ID, &review.Name, &review.Review, &review.AuthorID, &review.Rating, &createdAt)
+	if err == sql.ErrNoRows {
+		return Review{}, false, nil
+	}
+	if err != nil {
+		return Review{}, false, err
+	}
+	review.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return review, true, nil
+}
+
+func (s *SQLiteStore) List(offset, limit, authorID int) ([]Review, error) {
+	query := `SELECT id, name, review, author_id, rating, created_at FROM reviews`
+	args := []interface{}{}
+	if authorID != 0 {
+		query += ` WHERE author_id = ?`
+		args = append(args, authorID)
+	}
+	query += ` ORDER BY id ASC LIMIT ? OFFSET ?`
+	if limit <= 0 {
+		limit = -1 // SQLite treats a negative LIMIT as "no limit"
+	}
+	args = append(args, limit, offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviewsOut := []Review{}
+	for rows.Next() {
+		var review Review
+		var createdAt string
+		if err := rows.Scan(&review.ID, &review.Name, &review.Review, &review.AuthorID, &review.Rating, &createdAt); err != nil {
+			return nil, err
+		}
+		review.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		reviewsOut = append(reviewsOut, review)
+	}
+	return reviewsOut, rows.Err()
+}
+
+func (s *SQLiteStore) Delete(id int) error {
+	result, err := s.db.Exec(`DELETE FROM reviews WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Update(review Review) error {
+	result, err := s.db.Exec(
+		`UPDATE reviews SET name = ?, review = ?, author_id = ?, rating = ? WHERE id = ?`,
+		review.Name, review.Review, review.AuthorID, review.Rating, review.ID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
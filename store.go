@@ -0,0 +1,31 @@
+package main
+
+import "errors"
+
+// ErrReviewNotFound is returned by Store implementations when a review ID
+// doesn't exist.
+var ErrReviewNotFound = errors.New("review not found")
+
+// Store persists reviews. Implementations: JSONStore (the original flat-file
+// format), SQLiteStore and PostgresStore (indexed, concurrent-safe).
+type Store interface {
+	// Add assigns a new ID to review and persists it.
+	Add(review Review) (Review, error)
+
+	// Get looks up a single review by ID.
+	Get(id int) (Review, bool, error)
+
+	// List returns up to limit reviews starting at offset, most recently
+	// added last. If authorID is non-zero, results are restricted to that
+	// author.
+	List(offset, limit, authorID int) ([]Review, error)
+
+	// Delete removes a review by ID. Returns ErrReviewNotFound if it doesn't exist.
+	Delete(id int) error
+
+	// Update overwrites an existing review in place, matched by ID.
+	Update(review Review) error
+
+	// Close releases any underlying resources (file handles, DB connections).
+	Close() error
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// exerciseStore runs the same Add/Get/List/Delete/Update sequence against any
+// Store implementation, so JSONStore/SQLiteStore/PostgresStore are all held
+// to the same contract.
+func exerciseStore(t *testing.T, store Store) {
+	t.Helper()
+
+	alice, err := store.Add(Review{Name: "Alice", Review: "Great", AuthorID: 1})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if alice.ID == 0 {
+		t.Fatal("Add: expected a non-zero ID to be assigned")
+	}
+
+	bob, err := store.Add(Review{Name: "Bob", Review: "Meh", AuthorID: 2})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	carol, err := store.Add(Review{Name: "Carol", Review: "Also great", AuthorID: 1})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, ok, err := store.Get(alice.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !ok || got.Name != "Alice" {
+		t.Fatalf("Get(%d) = (%+v, %v), want Alice", alice.ID, got, ok)
+	}
+
+	if _, ok, err := store.Get(-1); err != nil || ok {
+		t.Fatalf("Get(-1) = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+
+	all, err := store.List(0, 0, 0)
+	if err != nil {
+		t.Fatalf("List(0,0,0): %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("List(0,0,0) returned %d reviews, want 3", len(all))
+	}
+
+	byAuthor, err := store.List(0, 0, 1)
+	if err != nil {
+		t.Fatalf("List(0,0,1): %v", err)
+	}
+	if len(byAuthor) != 2 {
+		t.Fatalf("List(0,0,1) returned %d reviews, want 2", len(byAuthor))
+	}
+	for _, r := range byAuthor {
+		if r.AuthorID != 1 {
+			t.Fatalf("List(0,0,1) returned review with AuthorID %d", r.AuthorID)
+		}
+	}
+
+	limited, err := store.List(0, 2, 0)
+	if err != nil {
+		t.Fatalf("List(0,2,0): %v", err)
+	}
+	if len(limited) != 2 {
+		t.Fatalf("List(0,2,0) returned %d reviews, want 2", len(limited))
+	}
+
+	rest, err := store.List(2, 0, 0)
+	if err != nil {
+		t.Fatalf("List(2,0,0): %v", err)
+	}
+	if len(rest) != 1 {
+		t.Fatalf("List(2,0,0) returned %d reviews, want 1", len(rest))
+	}
+
+	past, err := store.List(10, 0, 0)
+	if err != nil {
+		t.Fatalf("List(10,0,0): %v", err)
+	}
+	if len(past) != 0 {
+		t.Fatalf("List(10,0,0) returned %d reviews, want 0", len(past))
+	}
+
+	bob.Review = "Changed my mind, great"
+	if err := store.Update(bob); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _, err = store.Get(bob.ID)
+	if err != nil {
+		t.Fatalf("Get after Update: %v", err)
+	}
+	if got.Review != "Changed my mind, great" {
+		t.Fatalf("Get after Update: Review = %q, want %q", got.Review, "Changed my mind, great")
+	}
+
+	if err := store.Update(Review{ID: -1}); err != ErrReviewNotFound {
+		t.Fatalf("Update(missing) = %v, want ErrReviewNotFound", err)
+	}
+
+	if err := store.Delete(carol.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok, err := store.Get(carol.ID); err != nil || ok {
+		t.Fatalf("Get after Delete = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+
+	if err := store.Delete(carol.ID); err != ErrReviewNotFound {
+		t.Fatalf("Delete(already deleted) = %v, want ErrReviewNotFound", err)
+	}
+}
+
+func TestJSONStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	store, err := NewJSONStore(path)
+	if err != nil {
+		t.Fatalf("NewJSONStore: %v", err)
+	}
+	defer store.Close()
+
+	exerciseStore(t, store)
+}
+
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.db")
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	defer store.Close()
+
+	exerciseStore(t, store)
+}